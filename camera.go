@@ -15,15 +15,33 @@
 package twodee
 
 import (
+	"math"
+
 	"github.com/go-gl/gl"
 )
 
+type ProjectionMode int
+
+const (
+	Orthographic ProjectionMode = iota
+	Perspective
+)
+
 type Camera struct {
-	view   Rectangle
-	focus  Point
-	width  float64
-	height float64
-	zoom   float64
+	view       Rectangle
+	focus      Point
+	width      float64
+	height     float64
+	zoom       float64
+	projection ProjectionMode
+	fovY       float64
+	aspect     float64
+	near       float64
+	far        float64
+	vpX        int
+	vpY        int
+	vpW        int
+	vpH        int
 }
 
 func NewCamera(x float64, y float64, w float64, h float64) (c *Camera) {
@@ -32,6 +50,27 @@ func NewCamera(x float64, y float64, w float64, h float64) (c *Camera) {
 		height: h,
 		focus:  Pt(x+w/2.0, y+h/2.0),
 		zoom:   0,
+		vpW:    int(w),
+		vpH:    int(h),
+	}
+	c.calcView()
+	return
+}
+
+// NewPerspectiveCamera builds a Camera that projects with a perspective
+// matrix of vertical field of view fovY (degrees) instead of the default
+// orthographic projection.
+func NewPerspectiveCamera(fovY float64, aspect float64, near float64, far float64) (c *Camera) {
+	c = &Camera{
+		projection: Perspective,
+		fovY:       fovY,
+		aspect:     aspect,
+		near:       near,
+		far:        far,
+		width:      1,
+		height:     1 / aspect,
+		vpW:        1,
+		vpH:        1,
 	}
 	c.calcView()
 	return
@@ -40,10 +79,10 @@ func NewCamera(x float64, y float64, w float64, h float64) (c *Camera) {
 func (c *Camera) calcView() {
 	var (
 		ratio = c.height / c.width
-		hw = c.width / 2.0
-		hh = hw * ratio
-		zw = hw * c.zoom
-		zh = zw * ratio
+		hw    = c.width / 2.0
+		hh    = hw * ratio
+		zw    = hw * c.zoom
+		zh    = zw * ratio
 	)
 	c.view.Min.X = c.focus.X - hw - zw
 	c.view.Min.Y = c.focus.Y - hh - zh
@@ -55,6 +94,17 @@ func (c *Camera) MatchRatio(width int, height int) {
 	ratio := float64(height) / float64(width)
 	c.height = c.width * ratio
 	c.calcView()
+	c.SetViewport(0, 0, width, height)
+}
+
+// SetViewport records the GL viewport backing this camera and applies it,
+// so picking math in Unproject stays in sync with window resizes.
+func (c *Camera) SetViewport(x int, y int, w int, h int) {
+	c.vpX = x
+	c.vpY = y
+	c.vpW = w
+	c.vpH = h
+	gl.Viewport(x, y, w, h)
 }
 
 func (c *Camera) Top(y float64) {
@@ -76,12 +126,77 @@ func (c *Camera) Zoom(z float64) {
 	c.calcView()
 }
 
+// Bounds returns the world-space rectangle this camera currently sees, for
+// culling against. For a Perspective camera this is the near-plane extent
+// from frustum(), not the placeholder view calcView() derives from the
+// camera's nominal width and height.
 func (c *Camera) Bounds() Rectangle {
+	if c.projection == Perspective {
+		var left, right, bottom, top = c.frustum()
+		return Rectangle{Min: Pt(left, bottom), Max: Pt(right, top)}
+	}
 	return c.view
 }
 
+// ScreenToWorld converts a window pixel coordinate, as reported by mouse
+// events against a window sized windowWidth x windowHeight, into world
+// coordinates using the current view.
+func (c *Camera) ScreenToWorld(x int, y int, windowWidth int, windowHeight int) Point {
+	var (
+		u = float64(x) / float64(windowWidth)
+		v = float64(y) / float64(windowHeight)
+	)
+	return Pt(
+		c.view.Min.X+u*(c.view.Max.X-c.view.Min.X),
+		c.view.Min.Y+v*(c.view.Max.Y-c.view.Min.Y),
+	)
+}
+
+// frustum computes the near-plane bounds of the perspective projection,
+// off-axis shifted by focus and scaled by zoom so Pan and Zoom affect a
+// Perspective camera the same way they affect an Orthographic one. Top
+// moves focus by the distance from y to the placeholder view's Min.Y, so
+// it does not put an edge of this frustum at y. SetProjection and
+// Unproject share this so the projection and its inverse never drift
+// apart.
+func (c *Camera) frustum() (left float64, right float64, bottom float64, top float64) {
+	var (
+		halfH = c.near * math.Tan(c.fovY*math.Pi/360) * (1 + c.zoom)
+		halfW = halfH * c.aspect
+	)
+	left = c.focus.X - halfW
+	right = c.focus.X + halfW
+	bottom = c.focus.Y - halfH
+	top = c.focus.Y + halfH
+	return
+}
+
 func (c *Camera) SetProjection() {
 	gl.MatrixMode(gl.PROJECTION)
 	gl.LoadIdentity()
-	gl.Ortho(c.view.Min.X, c.view.Max.X, c.view.Max.Y, c.view.Min.Y, -1, 1)
+	switch c.projection {
+	case Perspective:
+		var left, right, bottom, top = c.frustum()
+		gl.Frustum(left, right, bottom, top, c.near, c.far)
+	default:
+		gl.Ortho(c.view.Min.X, c.view.Max.X, c.view.Max.Y, c.view.Min.Y, -1, 1)
+	}
+}
+
+// Unproject inverts the current projection, turning a window pixel
+// coordinate within this camera's viewport back into a world coordinate.
+// Required for hit-testing once perspective projection is in use.
+func (c *Camera) Unproject(screenX int, screenY int) Point {
+	var (
+		u = float64(screenX-c.vpX) / float64(c.vpW)
+		v = float64(screenY-c.vpY) / float64(c.vpH)
+	)
+	if c.projection == Perspective {
+		var left, right, bottom, top = c.frustum()
+		return Pt(left+u*(right-left), top-v*(top-bottom))
+	}
+	return Pt(
+		c.view.Min.X+u*(c.view.Max.X-c.view.Min.X),
+		c.view.Min.Y+v*(c.view.Max.Y-c.view.Min.Y),
+	)
 }
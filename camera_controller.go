@@ -0,0 +1,232 @@
+// Copyright 2014 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twodee
+
+import (
+	"math"
+	"time"
+)
+
+type Easing func(t float64) float64
+
+func LinearEasing(t float64) float64 {
+	return t
+}
+
+func CubicEasing(t float64) float64 {
+	return t * t * t
+}
+
+func ElasticEasing(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	var p = 0.3
+	return math.Pow(2, -10*t)*math.Sin((t-p/4)*(2*math.Pi)/p) + 1
+}
+
+type panTween struct {
+	active  bool
+	from    Point
+	to      Point
+	elapsed time.Duration
+	dur     time.Duration
+	easing  Easing
+}
+
+func (t *panTween) Update(dt time.Duration) (Point, bool) {
+	if !t.active {
+		return Point{}, false
+	}
+	t.elapsed += dt
+	if t.elapsed >= t.dur {
+		t.active = false
+		return t.to, true
+	}
+	var pct = t.easing(float64(t.elapsed) / float64(t.dur))
+	return Pt(t.from.X+(t.to.X-t.from.X)*pct, t.from.Y+(t.to.Y-t.from.Y)*pct), true
+}
+
+type zoomTween struct {
+	active  bool
+	from    float64
+	to      float64
+	elapsed time.Duration
+	dur     time.Duration
+	easing  Easing
+}
+
+func (t *zoomTween) Update(dt time.Duration) (float64, bool) {
+	if !t.active {
+		return 0, false
+	}
+	t.elapsed += dt
+	if t.elapsed >= t.dur {
+		t.active = false
+		return t.to, true
+	}
+	var pct = t.easing(float64(t.elapsed) / float64(t.dur))
+	return t.from + (t.to-t.from)*pct, true
+}
+
+type shakeState struct {
+	active    bool
+	amplitude float64
+	frequency float64
+	elapsed   time.Duration
+	dur       time.Duration
+}
+
+func (s *shakeState) Update(dt time.Duration) Point {
+	if !s.active {
+		return Pt(0, 0)
+	}
+	s.elapsed += dt
+	if s.elapsed >= s.dur {
+		s.active = false
+		return Pt(0, 0)
+	}
+	var (
+		t       = float64(s.elapsed) / float64(time.Second)
+		damping = 1.0 - float64(s.elapsed)/float64(s.dur)
+		ox      = math.Sin(t*s.frequency*2*math.Pi) * s.amplitude * damping
+		oy      = math.Sin(t*s.frequency*2*math.Pi*1.3+1.7) * s.amplitude * damping
+	)
+	return Pt(ox, oy)
+}
+
+// CameraController wraps a Camera, separating its tweenable pose (focus,
+// zoom) from the per-frame update logic needed to follow nodes, tween
+// toward new poses, shake the view, and keep the result inside world
+// bounds.
+type CameraController struct {
+	Camera *Camera
+
+	focus    Point
+	zoom     float64
+	follow   Node
+	deadzone Rectangle
+
+	pan   panTween
+	zoomT zoomTween
+	shake shakeState
+
+	clamp    Rectangle
+	hasClamp bool
+}
+
+func NewCameraController(c *Camera) *CameraController {
+	return &CameraController{
+		Camera: c,
+		focus:  c.focus,
+		zoom:   c.zoom,
+	}
+}
+
+func (cc *CameraController) Follow(n Node, deadzone Rectangle) {
+	cc.follow = n
+	cc.deadzone = deadzone
+}
+
+func (cc *CameraController) PanTo(p Point, dur time.Duration, easing Easing) {
+	cc.pan = panTween{active: true, from: cc.focus, to: p, dur: dur, easing: easing}
+}
+
+func (cc *CameraController) ZoomTo(z float64, dur time.Duration, easing Easing) {
+	cc.zoomT = zoomTween{active: true, from: cc.zoom, to: z, dur: dur, easing: easing}
+}
+
+func (cc *CameraController) Shake(amplitude float64, duration time.Duration, frequency float64) {
+	cc.shake = shakeState{active: true, amplitude: amplitude, frequency: frequency, dur: duration}
+}
+
+func (cc *CameraController) ClampBounds(r Rectangle) {
+	cc.clamp = r
+	cc.hasClamp = true
+}
+
+func (cc *CameraController) applyFollow() {
+	if cc.follow == nil {
+		return
+	}
+	var (
+		pos = cc.follow.Pos()
+		dx  float64
+		dy  float64
+	)
+	if pos.X < cc.focus.X+cc.deadzone.Min.X {
+		dx = pos.X - (cc.focus.X + cc.deadzone.Min.X)
+	} else if pos.X > cc.focus.X+cc.deadzone.Max.X {
+		dx = pos.X - (cc.focus.X + cc.deadzone.Max.X)
+	}
+	if pos.Y < cc.focus.Y+cc.deadzone.Min.Y {
+		dy = pos.Y - (cc.focus.Y + cc.deadzone.Min.Y)
+	} else if pos.Y > cc.focus.Y+cc.deadzone.Max.Y {
+		dy = pos.Y - (cc.focus.Y + cc.deadzone.Max.Y)
+	}
+	cc.focus.X += dx
+	cc.focus.Y += dy
+}
+
+func (cc *CameraController) applyClamp() {
+	var (
+		view = cc.Camera.view
+		dx   float64
+		dy   float64
+	)
+	if view.Max.X-view.Min.X <= cc.clamp.Max.X-cc.clamp.Min.X {
+		if view.Min.X < cc.clamp.Min.X {
+			dx = cc.clamp.Min.X - view.Min.X
+		} else if view.Max.X > cc.clamp.Max.X {
+			dx = cc.clamp.Max.X - view.Max.X
+		}
+	}
+	if view.Max.Y-view.Min.Y <= cc.clamp.Max.Y-cc.clamp.Min.Y {
+		if view.Min.Y < cc.clamp.Min.Y {
+			dy = cc.clamp.Min.Y - view.Min.Y
+		} else if view.Max.Y > cc.clamp.Max.Y {
+			dy = cc.clamp.Max.Y - view.Max.Y
+		}
+	}
+	if dx == 0 && dy == 0 {
+		return
+	}
+	cc.Camera.view.Min.X += dx
+	cc.Camera.view.Max.X += dx
+	cc.Camera.view.Min.Y += dy
+	cc.Camera.view.Max.Y += dy
+	cc.Camera.focus.X += dx
+	cc.Camera.focus.Y += dy
+}
+
+// Update advances follow, pan/zoom tweens, and shake by dt, then recomputes
+// the Camera's view exactly once so a shake during a follow-plus-zoom-in
+// still produces a single final calcView per tick.
+func (cc *CameraController) Update(dt time.Duration) {
+	cc.applyFollow()
+	if p, ok := cc.pan.Update(dt); ok {
+		cc.focus = p
+	}
+	if z, ok := cc.zoomT.Update(dt); ok {
+		cc.zoom = z
+	}
+	var offset = cc.shake.Update(dt)
+	cc.Camera.focus = Pt(cc.focus.X+offset.X, cc.focus.Y+offset.Y)
+	cc.Camera.zoom = cc.zoom
+	cc.Camera.calcView()
+	if cc.hasClamp {
+		cc.applyClamp()
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2014 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twodee
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEasingEndpoints(t *testing.T) {
+	for _, easing := range []Easing{LinearEasing, CubicEasing, ElasticEasing} {
+		if v := easing(0); v != 0 {
+			t.Errorf("easing(0) = %v, want 0", v)
+		}
+		if v := easing(1); v != 1 {
+			t.Errorf("easing(1) = %v, want 1", v)
+		}
+	}
+}
+
+func TestPanTweenReachesTarget(t *testing.T) {
+	var tween = panTween{
+		active: true,
+		from:   Pt(0, 0),
+		to:     Pt(10, 20),
+		dur:    time.Second,
+		easing: LinearEasing,
+	}
+	if p, ok := tween.Update(500 * time.Millisecond); !ok || p.X != 5 || p.Y != 10 {
+		t.Fatalf("mid tween = %+v, ok=%v, want {5 10}, true", p, ok)
+	}
+	p, ok := tween.Update(500 * time.Millisecond)
+	if !ok || p.X != 10 || p.Y != 20 {
+		t.Fatalf("final tween = %+v, ok=%v, want {10 20}, true", p, ok)
+	}
+	if tween.active {
+		t.Fatal("tween should be inactive once its duration has elapsed")
+	}
+}
+
+func TestShakeDecaysToZero(t *testing.T) {
+	var shake = shakeState{active: true, amplitude: 10, frequency: 5, dur: 200 * time.Millisecond}
+	shake.Update(100 * time.Millisecond)
+	if p := shake.Update(200 * time.Millisecond); p.X != 0 || p.Y != 0 {
+		t.Fatalf("shake past its duration = %+v, want zero offset", p)
+	}
+	if shake.active {
+		t.Fatal("shake should be inactive once its duration has elapsed")
+	}
+}
+
+func TestClampDoesNotDisturbFollowFocus(t *testing.T) {
+	var (
+		camera = NewCamera(-5, -5, 10, 10)
+		cc     = NewCameraController(camera)
+	)
+	cc.ClampBounds(Rectangle{Min: Pt(-5, -5), Max: Pt(5, 5)})
+	cc.Shake(100, time.Second, 10)
+	cc.Update(10 * time.Millisecond)
+	if cc.focus.X != 0 || cc.focus.Y != 0 {
+		t.Fatalf("clamp altered the persistent follow/pan focus: got %+v, want {0 0}", cc.focus)
+	}
+}
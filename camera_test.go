@@ -0,0 +1,69 @@
+// Copyright 2013 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twodee
+
+import (
+	"testing"
+)
+
+func TestScreenToWorldMatchesUnproject(t *testing.T) {
+	var camera = NewCamera(0, 0, 100, 100)
+	camera.vpX, camera.vpY, camera.vpW, camera.vpH = 0, 0, 200, 200
+	var (
+		want = camera.ScreenToWorld(50, 150, 200, 200)
+		got  = camera.Unproject(50, 150)
+	)
+	if want.X != got.X || want.Y != got.Y {
+		t.Fatalf("ScreenToWorld = %+v, Unproject = %+v, want equal for a matching viewport", want, got)
+	}
+}
+
+func TestNewPerspectiveCameraHasNonZeroViewport(t *testing.T) {
+	var camera = NewPerspectiveCamera(60, 1.5, 0.1, 100)
+	if camera.vpW == 0 || camera.vpH == 0 {
+		t.Fatalf("vpW=%d vpH=%d, want non-zero defaults so Unproject doesn't divide by zero", camera.vpW, camera.vpH)
+	}
+	camera.SetViewport(0, 0, 200, 200)
+	var p = camera.Unproject(100, 100)
+	if p.X != camera.focus.X || p.Y != camera.focus.Y {
+		t.Fatalf("Unproject at viewport center = %+v, want the camera focus %+v", p, camera.focus)
+	}
+}
+
+func TestUnprojectPerspectiveVerticalAxisMatchesScreenOrientation(t *testing.T) {
+	var camera = NewPerspectiveCamera(60, 1.5, 0.1, 100)
+	camera.SetViewport(0, 0, 200, 200)
+	var (
+		_, _, bottom, top = camera.frustum()
+		atScreenTop       = camera.Unproject(100, 0)
+		atScreenBottom    = camera.Unproject(100, 200)
+	)
+	if atScreenTop.Y != top {
+		t.Fatalf("Unproject at screen top Y = %v, want frustum top %v", atScreenTop.Y, top)
+	}
+	if atScreenBottom.Y != bottom {
+		t.Fatalf("Unproject at screen bottom Y = %v, want frustum bottom %v", atScreenBottom.Y, bottom)
+	}
+}
+
+func TestPerspectivePanMovesFrustum(t *testing.T) {
+	var camera = NewPerspectiveCamera(60, 1.5, 0.1, 100)
+	var left1, right1, _, _ = camera.frustum()
+	camera.Pan(5, 0)
+	var left2, right2, _, _ = camera.frustum()
+	if left2-left1 != 5 || right2-right1 != 5 {
+		t.Fatalf("Pan(5, 0) shifted the frustum by left=%v right=%v, want 5 for both", left2-left1, right2-right1)
+	}
+}
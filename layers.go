@@ -14,6 +14,23 @@
 
 package twodee
 
+import (
+	"github.com/go-gl/gl"
+)
+
+// MouseEnter, MouseLeave, and MouseDrag extend the base MouseEvent kinds
+// so Layers can report hover transitions and deliver drag moves to
+// whichever layer captured the gesture.
+const (
+	MouseEnter MouseEventKind = iota + 3
+	MouseLeave
+	MouseDrag
+)
+
+// Layer.HandleMouseEvent returns true when the layer consumed evt. A
+// layer that returns true for a MouseDown captures the mouse: it keeps
+// receiving events directly, bypassing hit order, up to and including the
+// next MouseUp, which releases capture whatever the layer returns for it.
 type Layer interface {
 	Render()
 	Update()
@@ -22,7 +39,9 @@ type Layer interface {
 }
 
 type Layers struct {
-	layers []Layer
+	layers   []Layer
+	captured Layer
+	hovered  Layer
 }
 
 func NewLayers() *Layers {
@@ -43,8 +62,36 @@ func (l *Layers) Pop() (layer Layer) {
 }
 
 func (l *Layers) Render() {
+	var masked bool
 	for _, layer := range l.layers {
-		layer.Render()
+		if t, ok := layer.(*MaskLayer); ok {
+			gl.Enable(gl.STENCIL_TEST)
+			gl.Clear(gl.STENCIL_BUFFER_BIT)
+			gl.StencilFunc(gl.ALWAYS, 1, 0xFF)
+			gl.StencilOp(gl.KEEP, gl.KEEP, gl.REPLACE)
+			gl.StencilMask(0xFF)
+			gl.ColorMask(false, false, false, false)
+			gl.DepthMask(false)
+			t.Render()
+			gl.ColorMask(true, true, true, true)
+			gl.DepthMask(true)
+			gl.StencilFunc(gl.EQUAL, 1, 0xFF)
+			gl.StencilMask(0x00)
+			masked = true
+			continue
+		}
+		if t, ok := layer.(*OffscreenLayer); ok {
+			t.Render()
+		} else {
+			layer.Render()
+		}
+		if masked {
+			gl.Disable(gl.STENCIL_TEST)
+			masked = false
+		}
+	}
+	if masked {
+		gl.Disable(gl.STENCIL_TEST)
 	}
 }
 
@@ -60,11 +107,75 @@ func (l *Layers) Delete() {
 	}
 }
 
+// HandleMouseEvent delivers evt to the topmost layer first, stopping at
+// the first layer that consumes it, and returns whether anything did. A
+// layer that captured an earlier MouseDown receives evt directly,
+// regardless of hit order, until it releases capture on MouseUp. A
+// MouseMove also updates which layer is considered hovered, sending it
+// MouseEnter and MouseLeave as that changes.
 func (l *Layers) HandleMouseEvent(evt *MouseEvent) bool {
-	for _, layer := range l.layers {
-		if layer.HandleMouseEvent(evt) == false {
-			return false
+	if l.captured != nil {
+		var consumed = l.captured.HandleMouseEvent(evt)
+		if evt.Kind == MouseUp {
+			l.captured = nil
 		}
+		return consumed
+	}
+	var hit Layer
+	for i := len(l.layers) - 1; i >= 0; i-- {
+		if l.layers[i].HandleMouseEvent(evt) {
+			hit = l.layers[i]
+			if evt.Kind == MouseDown {
+				l.captured = l.layers[i]
+			}
+			break
+		}
+	}
+	if evt.Kind == MouseMove {
+		l.updateHover(hit, evt)
+	}
+	return hit != nil
+}
+
+// updateHover sends MouseLeave to the previously hovered layer and
+// MouseEnter to hit when the hit-tested layer for a MouseMove changes.
+func (l *Layers) updateHover(hit Layer, evt *MouseEvent) {
+	if hit == l.hovered {
+		return
+	}
+	if l.hovered != nil {
+		l.hovered.HandleMouseEvent(&MouseEvent{Kind: MouseLeave, X: evt.X, Y: evt.Y})
 	}
+	if hit != nil {
+		hit.HandleMouseEvent(&MouseEvent{Kind: MouseEnter, X: evt.X, Y: evt.Y})
+	}
+	l.hovered = hit
+}
+
+// ModalLayer wraps a layer that must get exclusive input, such as a
+// dialog or menu. It swallows every mouse event not consumed by its
+// wrapped layer so layers beneath it never see them.
+type ModalLayer struct {
+	layer Layer
+}
+
+func NewModalLayer(layer Layer) *ModalLayer {
+	return &ModalLayer{layer: layer}
+}
+
+func (m *ModalLayer) Render() {
+	m.layer.Render()
+}
+
+func (m *ModalLayer) Update() {
+	m.layer.Update()
+}
+
+func (m *ModalLayer) Delete() {
+	m.layer.Delete()
+}
+
+func (m *ModalLayer) HandleMouseEvent(evt *MouseEvent) bool {
+	m.layer.HandleMouseEvent(evt)
 	return true
-}
\ No newline at end of file
+}
@@ -0,0 +1,164 @@
+// Copyright 2014 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twodee
+
+import (
+	"github.com/go-gl/gl"
+)
+
+// MaskLayer renders its wrapped layer into the stencil buffer instead of
+// the color buffer. Layers.Render gates the next sibling layer's draw on
+// the resulting stencil so it only appears where the mask did (flashlight
+// cones, fog-of-war holes, spotlight cutouts).
+type MaskLayer struct {
+	layer Layer
+}
+
+func NewMaskLayer(layer Layer) *MaskLayer {
+	return &MaskLayer{layer: layer}
+}
+
+func (m *MaskLayer) Render() {
+	m.layer.Render()
+}
+
+func (m *MaskLayer) Update() {
+	m.layer.Update()
+}
+
+func (m *MaskLayer) Delete() {
+	m.layer.Delete()
+}
+
+func (m *MaskLayer) HandleMouseEvent(evt *MouseEvent) bool {
+	return m.layer.HandleMouseEvent(evt)
+}
+
+// OffscreenLayer renders its wrapped layer into an FBO-backed texture
+// instead of the default framebuffer, so later layers can sample it for
+// post effects such as blur, color grading, or screen-space distortion.
+type OffscreenLayer struct {
+	layer   Layer
+	width   int
+	height  int
+	fbo     gl.Framebuffer
+	texture gl.Texture
+	depth   gl.Renderbuffer
+}
+
+func NewOffscreenLayer(layer Layer, width int, height int) *OffscreenLayer {
+	var o = &OffscreenLayer{layer: layer, width: width, height: height}
+	o.texture = gl.GenTexture()
+	o.texture.Bind(gl.TEXTURE_2D)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA, width, height, 0, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	o.depth = gl.GenRenderbuffer()
+	o.depth.Bind()
+	gl.RenderbufferStorage(gl.RENDERBUFFER, gl.DEPTH_COMPONENT, width, height)
+	o.fbo = gl.GenFramebuffer()
+	o.fbo.Bind()
+	o.fbo.Texture2D(gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, o.texture, 0)
+	o.fbo.Renderbuffer(gl.DEPTH_ATTACHMENT, o.depth)
+	gl.Framebuffer(0).Bind()
+	return o
+}
+
+func (o *OffscreenLayer) Texture() gl.Texture {
+	return o.texture
+}
+
+func (o *OffscreenLayer) Render() {
+	o.fbo.Bind()
+	gl.Viewport(0, 0, o.width, o.height)
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	o.layer.Render()
+	gl.Framebuffer(0).Bind()
+}
+
+func (o *OffscreenLayer) Update() {
+	o.layer.Update()
+}
+
+func (o *OffscreenLayer) Delete() {
+	o.layer.Delete()
+	o.texture.Delete()
+	o.depth.Delete()
+	o.fbo.Delete()
+}
+
+func (o *OffscreenLayer) HandleMouseEvent(evt *MouseEvent) bool {
+	return o.layer.HandleMouseEvent(evt)
+}
+
+// PostEffect is a named shader program that samples a previous
+// OffscreenLayer's texture and draws a full-screen quad with it, e.g. for
+// blur, color grading, or screen-space distortion.
+type PostEffect struct {
+	Name    string
+	program gl.Program
+}
+
+// PostEffects is a small registry so callers can name post effects once
+// and apply them by name against whichever OffscreenLayer produced the
+// texture they want to consume.
+type PostEffects struct {
+	effects map[string]*PostEffect
+}
+
+func NewPostEffects() *PostEffects {
+	return &PostEffects{effects: make(map[string]*PostEffect)}
+}
+
+func (p *PostEffects) Register(name string, vertexSrc string, fragmentSrc string) *PostEffect {
+	var (
+		vertex   = gl.CreateShader(gl.VERTEX_SHADER)
+		fragment = gl.CreateShader(gl.FRAGMENT_SHADER)
+		program  = gl.CreateProgram()
+	)
+	vertex.Source(vertexSrc)
+	vertex.Compile()
+	fragment.Source(fragmentSrc)
+	fragment.Compile()
+	program.AttachShader(vertex)
+	program.AttachShader(fragment)
+	program.Link()
+	var effect = &PostEffect{Name: name, program: program}
+	p.effects[name] = effect
+	return effect
+}
+
+// Apply binds the named post effect and draws a full-screen quad sampling
+// source, leaving the result in whichever framebuffer is currently bound.
+func (p *PostEffects) Apply(name string, source gl.Texture) {
+	var effect, ok = p.effects[name]
+	if !ok {
+		return
+	}
+	effect.program.Use()
+	gl.ActiveTexture(gl.TEXTURE0)
+	source.Bind(gl.TEXTURE_2D)
+	gl.Begin(gl.QUADS)
+	gl.TexCoord2d(0, 0)
+	gl.Vertex2d(-1, -1)
+	gl.TexCoord2d(1, 0)
+	gl.Vertex2d(1, -1)
+	gl.TexCoord2d(1, 1)
+	gl.Vertex2d(1, 1)
+	gl.TexCoord2d(0, 1)
+	gl.Vertex2d(-1, 1)
+	gl.End()
+	gl.Program(0).Use()
+}
@@ -0,0 +1,74 @@
+// Copyright 2014 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twodee
+
+import (
+	"testing"
+)
+
+type countingLayer struct {
+	rendered int
+	updated  int
+	deleted  int
+	consume  bool
+}
+
+func (l *countingLayer) Render() {
+	l.rendered++
+}
+
+func (l *countingLayer) Update() {
+	l.updated++
+}
+
+func (l *countingLayer) Delete() {
+	l.deleted++
+}
+
+func (l *countingLayer) HandleMouseEvent(evt *MouseEvent) bool {
+	return l.consume
+}
+
+func TestMaskLayerDelegatesToWrappedLayer(t *testing.T) {
+	var (
+		inner = &countingLayer{consume: true}
+		mask  = NewMaskLayer(inner)
+	)
+	mask.Render()
+	mask.Update()
+	if !mask.HandleMouseEvent(&MouseEvent{}) {
+		t.Fatal("MaskLayer.HandleMouseEvent should return whatever its wrapped layer returns")
+	}
+	mask.Delete()
+	if inner.rendered != 1 || inner.updated != 1 || inner.deleted != 1 {
+		t.Fatalf("inner = %+v, want Render/Update/Delete each called once via the mask", inner)
+	}
+}
+
+func TestLayersRenderRendersMaskAndSiblingsOnce(t *testing.T) {
+	var (
+		masked = &countingLayer{}
+		first  = &countingLayer{}
+		second = &countingLayer{}
+		layers = NewLayers()
+	)
+	layers.Push(NewMaskLayer(masked))
+	layers.Push(first)
+	layers.Push(second)
+	layers.Render()
+	if masked.rendered != 1 || first.rendered != 1 || second.rendered != 1 {
+		t.Fatalf("masked=%+v first=%+v second=%+v, want every layer rendered exactly once", masked, first, second)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2014 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twodee
+
+import (
+	"testing"
+)
+
+type stubLayer struct {
+	consume bool
+	seen    int
+}
+
+func (l *stubLayer) Render() {}
+
+func (l *stubLayer) Update() {}
+
+func (l *stubLayer) Delete() {}
+
+func (l *stubLayer) HandleMouseEvent(evt *MouseEvent) bool {
+	l.seen++
+	return l.consume
+}
+
+func TestLayersHandleMouseEventTopmostFirst(t *testing.T) {
+	var (
+		bottom = &stubLayer{consume: true}
+		top    = &stubLayer{consume: true}
+		layers = NewLayers()
+	)
+	layers.Push(bottom)
+	layers.Push(top)
+	if !layers.HandleMouseEvent(&MouseEvent{}) {
+		t.Fatal("expected the event to be consumed")
+	}
+	if top.seen != 1 || bottom.seen != 0 {
+		t.Fatalf("top.seen=%d bottom.seen=%d, want topmost layer to get first crack and consume it", top.seen, bottom.seen)
+	}
+}
+
+func TestLayersHandleMouseEventReturnsFalseWhenUnconsumed(t *testing.T) {
+	var layers = NewLayers()
+	layers.Push(&stubLayer{consume: false})
+	layers.Push(&stubLayer{consume: false})
+	if layers.HandleMouseEvent(&MouseEvent{}) {
+		t.Fatal("expected no layer to consume the event")
+	}
+}
+
+func TestLayersCaptureFollowsDrag(t *testing.T) {
+	var (
+		bottom = &stubLayer{consume: true}
+		top    = &stubLayer{consume: false}
+		layers = NewLayers()
+	)
+	layers.Push(bottom)
+	layers.Push(top)
+	layers.HandleMouseEvent(&MouseEvent{Kind: MouseDown})
+	if bottom.seen != 1 || top.seen != 1 {
+		t.Fatalf("bottom.seen=%d top.seen=%d, want both hit-tested once before bottom captures on MouseDown", bottom.seen, top.seen)
+	}
+	layers.HandleMouseEvent(&MouseEvent{Kind: MouseDrag})
+	if bottom.seen != 2 || top.seen != 1 {
+		t.Fatalf("bottom.seen=%d top.seen=%d, want only the captured layer to see the drag", bottom.seen, top.seen)
+	}
+	layers.HandleMouseEvent(&MouseEvent{Kind: MouseUp})
+	layers.HandleMouseEvent(&MouseEvent{})
+	if top.seen != 2 {
+		t.Fatalf("top.seen=%d, want 2: capture released on MouseUp, so the plain event after it hit-tests top again", top.seen)
+	}
+}
+
+func TestModalLayerSwallowsUnconsumedEvents(t *testing.T) {
+	var (
+		inner  = &stubLayer{consume: false}
+		modal  = NewModalLayer(inner)
+		below  = &stubLayer{consume: true}
+		layers = NewLayers()
+	)
+	layers.Push(below)
+	layers.Push(modal)
+	if !layers.HandleMouseEvent(&MouseEvent{}) {
+		t.Fatal("expected ModalLayer to consume the event even though its wrapped layer did not")
+	}
+	if below.seen != 0 {
+		t.Fatalf("below.seen=%d, want 0: ModalLayer must swallow events before they reach layers beneath it", below.seen)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2012 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twodee
+
+// Node is anything that can live in a Scene's graph: positioned, drawn in
+// depth order, and able to invalidate the cached draw list that holds it
+// when its depth or membership in the tree changes.
+type Node interface {
+	Z() float64
+	SetZ(z float64)
+	Pos() Point
+	AABB() Rectangle
+	Draw()
+	MarkDirty()
+}
+
+// Element is the base a scene node embeds for child management, depth,
+// and position. Add/Remove propagate the dirty bit that invalidates the
+// owning Scene's cached draw list to whichever Element they're called on,
+// anywhere in the tree, not just Scene's own direct children.
+type Element struct {
+	children []Node
+	pos      Point
+	size     Point
+	z        float64
+	dirty    *bool
+}
+
+func (e *Element) Z() float64 {
+	return e.z
+}
+
+func (e *Element) SetZ(z float64) {
+	e.z = z
+	e.MarkDirty()
+}
+
+func (e *Element) Pos() Point {
+	return e.pos
+}
+
+func (e *Element) SetPos(p Point) {
+	e.pos = p
+}
+
+func (e *Element) AABB() Rectangle {
+	return Rectangle{
+		Min: e.pos,
+		Max: Pt(e.pos.X+e.size.X, e.pos.Y+e.size.Y),
+	}
+}
+
+func (e *Element) Draw() {
+}
+
+// MarkDirty flips the dirty bit belonging to whichever Scene this Element
+// is (transitively) part of. It is a no-op until that Element has been
+// Added under a Scene constructed with NewScene.
+func (e *Element) MarkDirty() {
+	if e.dirty != nil {
+		*e.dirty = true
+	}
+}
+
+func (e *Element) inheritDirty(dirty *bool) {
+	e.dirty = dirty
+	for _, child := range e.children {
+		if d, ok := child.(interface {
+			inheritDirty(*bool)
+		}); ok {
+			d.inheritDirty(dirty)
+		}
+	}
+}
+
+// Add adds child to this Element, marking the owning Scene's draw list
+// dirty and handing child the same dirty bit so further changes anywhere
+// beneath it reach the same Scene.
+func (e *Element) Add(child Node) {
+	e.children = append(e.children, child)
+	if d, ok := child.(interface {
+		inheritDirty(*bool)
+	}); ok {
+		d.inheritDirty(e.dirty)
+	}
+	e.MarkDirty()
+}
+
+// Remove removes child from this Element, marking the owning Scene's
+// draw list dirty so Draw stops referencing it immediately.
+func (e *Element) Remove(child Node) {
+	for i, c := range e.children {
+		if c == child {
+			e.children = append(e.children[:i], e.children[i+1:]...)
+			break
+		}
+	}
+	e.MarkDirty()
+}
+
+// GetAllChildren flattens the full subtree rooted at e, which is what
+// Scene.Draw sorts and culls.
+func (e *Element) GetAllChildren() []Node {
+	var all []Node
+	for _, child := range e.children {
+		all = append(all, child)
+		if p, ok := child.(interface{ GetAllChildren() []Node }); ok {
+			all = append(all, p.GetAllChildren()...)
+		}
+	}
+	return all
+}
@@ -32,16 +32,81 @@ func (s ByDepth) Swap(i int, j int) {
 	s[i], s[j] = s[j], s[i]
 }
 
+// SceneStats reports how many nodes Scene.Draw re-sorted (zero when it
+// reused the cached draw list), drew, and culled on the last frame, for
+// profiling culling and re-sort frequency.
+type SceneStats struct {
+	Sorted int
+	Drawn  int
+	Culled int
+}
+
 type Scene struct {
 	Element
 	*Camera
 	*Font
+
+	margin   float64
+	dirty    bool
+	drawList []Node
+	stats    SceneStats
+}
+
+// NewScene builds a Scene and wires its Element so Add, Remove, and
+// SetZ anywhere in the resulting tree - not just Scene's direct children
+// - mark this Scene's cached draw list dirty. Scenes not built with
+// NewScene never invalidate their cache.
+func NewScene(camera *Camera, font *Font) *Scene {
+	var s = &Scene{Camera: camera, Font: font}
+	s.Element.dirty = &s.dirty
+	return s
+}
+
+// SetCullMargin expands the camera bounds used to cull nodes in Draw by m
+// world units on each side, so fast-moving or slightly oversized nodes
+// near the edge of view aren't dropped a frame early.
+func (s *Scene) SetCullMargin(m float64) {
+	s.margin = m
+}
+
+func (s *Scene) Stats() SceneStats {
+	return s.stats
+}
+
+func expandRect(r Rectangle, margin float64) Rectangle {
+	return Rectangle{
+		Min: Pt(r.Min.X-margin, r.Min.Y-margin),
+		Max: Pt(r.Max.X+margin, r.Max.Y+margin),
+	}
+}
+
+func rectsIntersect(a Rectangle, b Rectangle) bool {
+	return a.Min.X <= b.Max.X && a.Max.X >= b.Min.X &&
+		a.Min.Y <= b.Max.Y && a.Max.Y >= b.Min.Y
 }
 
 func (s *Scene) Draw() {
-	l := s.GetAllChildren()
-	sort.Sort(ByDepth(l))
-	for _, c := range l {
+	var sorted int
+	if s.dirty || s.drawList == nil {
+		s.drawList = s.GetAllChildren()
+		sort.Stable(ByDepth(s.drawList))
+		s.dirty = false
+		sorted = len(s.drawList)
+	}
+	var (
+		bounds = expandRect(s.Camera.Bounds(), s.margin)
+		drawn  int
+	)
+	for _, c := range s.drawList {
+		if !rectsIntersect(bounds, c.AABB()) {
+			continue
+		}
 		c.Draw()
+		drawn++
+	}
+	s.stats = SceneStats{
+		Sorted: sorted,
+		Drawn:  drawn,
+		Culled: len(s.drawList) - drawn,
 	}
 }
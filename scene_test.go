@@ -0,0 +1,85 @@
+// Copyright 2012 Arne Roomann-Kurrik
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twodee
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestExpandRect(t *testing.T) {
+	var (
+		r = Rectangle{Min: Pt(0, 0), Max: Pt(10, 10)}
+		e = expandRect(r, 2)
+	)
+	if e.Min.X != -2 || e.Min.Y != -2 || e.Max.X != 12 || e.Max.Y != 12 {
+		t.Fatalf("expandRect(r, 2) = %+v, want Min{-2 -2} Max{12 12}", e)
+	}
+}
+
+func TestRectsIntersect(t *testing.T) {
+	var base = Rectangle{Min: Pt(0, 0), Max: Pt(10, 10)}
+	var cases = []struct {
+		other Rectangle
+		want  bool
+	}{
+		{Rectangle{Min: Pt(5, 5), Max: Pt(15, 15)}, true},
+		{Rectangle{Min: Pt(10, 10), Max: Pt(20, 20)}, true},
+		{Rectangle{Min: Pt(11, 11), Max: Pt(20, 20)}, false},
+		{Rectangle{Min: Pt(-20, -20), Max: Pt(-11, -11)}, false},
+	}
+	for _, c := range cases {
+		if got := rectsIntersect(base, c.other); got != c.want {
+			t.Errorf("rectsIntersect(%+v, %+v) = %v, want %v", base, c.other, got, c.want)
+		}
+	}
+}
+
+type stubNode struct {
+	z float64
+}
+
+func (n *stubNode) Z() float64 {
+	return n.z
+}
+
+func (n *stubNode) SetZ(z float64) {
+	n.z = z
+}
+
+func (n *stubNode) MarkDirty() {}
+
+func (n *stubNode) Draw() {}
+
+func (n *stubNode) AABB() Rectangle {
+	return Rectangle{}
+}
+
+func (n *stubNode) Pos() Point {
+	return Point{}
+}
+
+func TestByDepthIsStableUnderSort(t *testing.T) {
+	var (
+		a     = &stubNode{z: 1}
+		b     = &stubNode{z: 1}
+		c     = &stubNode{z: 0}
+		nodes = ByDepth{a, b, c}
+	)
+	sort.Stable(nodes)
+	if nodes[0] != c || nodes[1] != a || nodes[2] != b {
+		t.Fatalf("sort.Stable(ByDepth) reordered equal-Z siblings: got %+v", nodes)
+	}
+}